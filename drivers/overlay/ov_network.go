@@ -668,6 +668,51 @@ func (n *network) cleanupStaleSandboxes() {
 		})
 }
 
+// sandboxKey returns the key of the network sandbox created at the given
+// init epoch. It only depends on the epoch and on the network id, so the
+// same key can be derived again after a daemon restart.
+func (n *network) sandboxKey(epoch int) string {
+	return osl.GenerateKey(fmt.Sprintf("%d-", epoch) + n.id)
+}
+
+// restoreSandboxKey searches the net namespaces for the sandbox this network
+// created in a previous daemon life and returns its key. The network init
+// epoch is moved forward to the one of the found sandbox so that a later
+// sandbox re-creation does not collide with it.
+func (n *network) restoreSandboxKey() (string, error) {
+	epoch := -1
+	filepath.Walk(filepath.Dir(osl.GenerateKey("walk")),
+		func(path string, info os.FileInfo, err error) error {
+			_, fname := filepath.Split(path)
+
+			pList := strings.SplitN(fname, "-", 2)
+			if len(pList) != 2 || pList[1] == "" || !strings.HasPrefix(n.id, pList[1]) {
+				return nil
+			}
+
+			e, err := strconv.Atoi(pList[0])
+			if err != nil {
+				return nil
+			}
+
+			if e > epoch {
+				epoch = e
+			}
+
+			return nil
+		})
+
+	if epoch < 0 {
+		return "", fmt.Errorf("could not find the sandbox of network %s to restore", n.id)
+	}
+
+	n.Lock()
+	n.initEpoch = epoch
+	n.Unlock()
+
+	return n.sandboxKey(epoch), nil
+}
+
 func (n *network) initSandbox(restore bool) error {
 	n.Lock()
 	n.initEpoch++
@@ -692,9 +737,14 @@ func (n *network) initSandbox(restore bool) error {
 	// searching the net namespaces.
 	var key string
 	if restore {
-		key = osl.GenerateKey("-" + n.id)
+		var err error
+		if key, err = n.restoreSandboxKey(); err != nil {
+			return err
+		}
 	} else {
-		key = osl.GenerateKey(fmt.Sprintf("%d-", n.initEpoch) + n.id)
+		n.Lock()
+		key = n.sandboxKey(n.initEpoch)
+		n.Unlock()
 	}
 
 	sbox, err := osl.NewSandbox(key, !hostMode, restore)
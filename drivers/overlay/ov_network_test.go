@@ -0,0 +1,67 @@
+package overlay
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/docker/libnetwork/osl"
+	"github.com/docker/libnetwork/testutils"
+)
+
+func TestSandboxKeyRestore(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	dir, err := ioutil.TempDir("", "overlay-netns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	osl.SetBasePath(dir)
+
+	n := &network{id: "3f4b2b1d7a0c9e8f"}
+	n.initEpoch = 3
+	key := n.sandboxKey(n.initEpoch)
+	if key != n.sandboxKey(3) {
+		t.Fatalf("sandbox key is not reproducible: %s != %s", key, n.sandboxKey(3))
+	}
+
+	sbox, err := osl.NewSandbox(key, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sbox.Destroy()
+
+	// A sandbox from an older epoch and one of another network
+	// must not be picked up
+	for _, k := range []string{(&network{id: n.id}).sandboxKey(1), (&network{id: "a1b2c3d4e5f6"}).sandboxKey(7)} {
+		if err := ioutil.WriteFile(k, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Simulate a daemon restart
+	rn := &network{id: n.id}
+	rkey, err := rn.restoreSandboxKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rkey != key {
+		t.Fatalf("restored sandbox key %s does not match the original %s", rkey, key)
+	}
+	if rn.initEpoch != n.initEpoch {
+		t.Fatalf("restored init epoch %d does not match the original %d", rn.initEpoch, n.initEpoch)
+	}
+
+	rsbox, err := osl.NewSandbox(rkey, true, true)
+	if err != nil {
+		t.Fatalf("failed to reattach to the network sandbox: %v", err)
+	}
+	if rsbox.Key() != sbox.Key() {
+		t.Fatalf("reattached to sandbox %s instead of %s", rsbox.Key(), sbox.Key())
+	}
+
+	if _, err := (&network{id: "ffffffffffff"}).restoreSandboxKey(); err == nil {
+		t.Fatal("expected an error restoring the sandbox of an unknown network")
+	}
+}